@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// envFlags collects repeated `-e KEY=VALUE` flags into a flag.Value
+type envFlags []string
+
+func (e *envFlags) String() string {
+	return strings.Join(*e, ",")
+}
+
+func (e *envFlags) Set(value string) error {
+	*e = append(*e, value)
+	return nil
+}
+
+// parse turns the collected KEY=VALUE assignments into a map, or an error
+// if any assignment is malformed.
+func (e envFlags) parse() (map[string]string, error) {
+	if len(e) == 0 {
+		return nil, nil
+	}
+
+	env := make(map[string]string, len(e))
+	for _, assignment := range e {
+		key, value, ok := strings.Cut(assignment, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid -e value '%s', expected KEY=VALUE", assignment)
+		}
+		env[key] = value
+	}
+	return env, nil
+}
+
+// mergedEnvForConfig computes the effective environment for a configuration:
+// NewClaudeSettings defaults, overridden by the configuration's BaseURL/API
+// key, Model, and Env - in that order, matching the precedence applied by
+// setUnixSettingsFile and setWindowsEnvironmentVariables on activation.
+func mergedEnvForConfig(conf *Configuration) (map[string]string, error) {
+	apiKey, err := resolveAPIKey(conf.APIKey)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]string)
+	for k, v := range NewClaudeSettings().Env {
+		merged[k] = fmt.Sprintf("%v", v)
+	}
+
+	merged["ANTHROPIC_BASE_URL"] = conf.BaseURL
+	merged["ANTHROPIC_AUTH_TOKEN"] = apiKey
+
+	if conf.Model != "" {
+		merged["ANTHROPIC_MODEL"] = conf.Model
+	}
+	for k, v := range conf.Env {
+		merged[k] = v
+	}
+
+	return merged, nil
+}
+
+// printEnvForConfiguration prints a configuration's merged env in shell-eval
+// form so it can be consumed with `eval "$(ccc env <name>)"`.
+func printEnvForConfiguration(name string) error {
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	conf := findConfiguration(config, name)
+	if conf == nil {
+		return fmt.Errorf("configuration with name '%s' not found", name)
+	}
+
+	env, err := mergedEnvForConfig(conf)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := env[key]
+		if runtime.GOOS == "windows" {
+			fmt.Printf("set %s=%s\n", key, value)
+		} else {
+			fmt.Printf("export %s=%s\n", key, shellQuote(value))
+		}
+	}
+
+	return nil
+}
+
+// shellQuote wraps value in single quotes for safe use in POSIX shell eval,
+// escaping any embedded single quotes.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// healthResult is the outcome of probing a configuration's BaseURL
+type healthResult struct {
+	Name       string        `json:"name"`
+	StatusCode int           `json:"status_code,omitempty"`
+	Latency    time.Duration `json:"-"`
+	LatencyMS  int64         `json:"latency_ms"`
+	Accepted   bool          `json:"accepted"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// checkHealth probes a configuration's BaseURL with a lightweight GET
+// /v1/models request, falling back to a 1-token dry-run POST /v1/messages
+// for proxies that don't implement /v1/models.
+func checkHealth(conf Configuration, timeout time.Duration) healthResult {
+	result := healthResult{Name: conf.Name}
+
+	apiKey, err := resolveAPIKey(conf.APIKey)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	client := http.Client{Timeout: timeout}
+	baseURL := strings.TrimRight(conf.BaseURL, "/")
+
+	start := time.Now()
+	statusCode, err := probeModels(&client, baseURL, apiKey)
+	if err != nil {
+		statusCode, err = probeMessagesDryRun(&client, baseURL, apiKey)
+	}
+	result.Latency = time.Since(start)
+	result.LatencyMS = result.Latency.Milliseconds()
+
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.StatusCode = statusCode
+	result.Accepted = statusCode >= 200 && statusCode < 300
+	return result
+}
+
+func probeModels(client *http.Client, baseURL, apiKey string) (int, error) {
+	req, err := http.NewRequest(http.MethodGet, baseURL+"/v1/models", nil)
+	if err != nil {
+		return 0, err
+	}
+	setAuthHeaders(req, apiKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// A proxy that doesn't implement /v1/models at all (common) reports this
+	// as 404 or 405 rather than a 5xx - fall back to the dry-run for those too.
+	if resp.StatusCode >= 500 || resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed {
+		return resp.StatusCode, fmt.Errorf("GET /v1/models returned %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func probeMessagesDryRun(client *http.Client, baseURL, apiKey string) (int, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"model":      "claude-3-haiku-20240307",
+		"max_tokens": 1,
+		"messages": []map[string]string{
+			{"role": "user", "content": "ping"},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, baseURL+"/v1/messages", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	setAuthHeaders(req, apiKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+func setAuthHeaders(req *http.Request, apiKey string) {
+	req.Header.Set("x-api-key", apiKey)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+}
+
+// runTestCommand implements `ccc test`
+func runTestCommand(name string, all bool, timeout time.Duration, jsonOutput bool) error {
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	var targets []Configuration
+	switch {
+	case all:
+		targets = config.Configurations
+	case name != "":
+		conf := findConfiguration(config, name)
+		if conf == nil {
+			return fmt.Errorf("configuration with name '%s' not found", name)
+		}
+		targets = []Configuration{*conf}
+	default:
+		return fmt.Errorf("either -n <name> or --all is required")
+	}
+
+	if len(targets) == 0 {
+		fmt.Println("No configurations found.")
+		return nil
+	}
+
+	results := make([]healthResult, 0, len(targets))
+	for _, conf := range targets {
+		results = append(results, checkHealth(conf, timeout))
+	}
+
+	if jsonOutput {
+		return json.NewEncoder(os.Stdout).Encode(results)
+	}
+
+	for _, r := range results {
+		if r.Error != "" {
+			fmt.Printf("%-20s unreachable: %s\n", r.Name, r.Error)
+			continue
+		}
+		status := "rejected"
+		if r.Accepted {
+			status = "ok"
+		}
+		fmt.Printf("%-20s %-8s status=%d latency=%s\n", r.Name, status, r.StatusCode, r.Latency)
+	}
+
+	return nil
+}
+
+// healthColumn renders a one-word summary for the `list --check` Health column
+func healthColumn(r healthResult) string {
+	if r.Error != "" {
+		return "unreachable"
+	}
+	if r.Accepted {
+		return "ok"
+	}
+	return fmt.Sprintf("rejected(%d)", r.StatusCode)
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var envVarSanitizer = regexp.MustCompile(`[^A-Z0-9_]+`)
+
+// envVarNameForProfile derives a ${CCC_<NAME>_API_KEY} placeholder name from
+// a profile name, e.g. "work" -> "CCC_WORK_API_KEY".
+func envVarNameForProfile(name string) string {
+	sanitized := envVarSanitizer.ReplaceAllString(strings.ToUpper(name), "_")
+	return fmt.Sprintf("CCC_%s_API_KEY", sanitized)
+}
+
+// isYAMLPath reports whether path's extension indicates YAML rather than JSON
+func isYAMLPath(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// exportConfigurations writes one or more configurations as JSON or YAML,
+// optionally redacting API keys to ${ENV_VAR} placeholders so the result can
+// be safely committed to a dotfiles repo.
+func exportConfigurations(name string, all bool, outPath string, redact bool) error {
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	var targets []Configuration
+	switch {
+	case all:
+		targets = config.Configurations
+	case name != "":
+		conf := findConfiguration(config, name)
+		if conf == nil {
+			return fmt.Errorf("configuration with name '%s' not found", name)
+		}
+		targets = []Configuration{*conf}
+	default:
+		return fmt.Errorf("either -n <name> or --all is required")
+	}
+
+	export := ConfigFile{Configurations: make([]Configuration, len(targets))}
+	for i, conf := range targets {
+		if redact {
+			conf.APIKey = "${" + envVarNameForProfile(conf.Name) + "}"
+		} else {
+			apiKey, err := resolveAPIKey(conf.APIKey)
+			if err != nil {
+				return fmt.Errorf("failed to resolve API key for '%s': %w", conf.Name, err)
+			}
+			conf.APIKey = apiKey
+		}
+		export.Configurations[i] = conf
+	}
+
+	data, err := marshalConfigFile(&export, outPath)
+	if err != nil {
+		return err
+	}
+
+	if outPath == "" {
+		fmt.Print(string(data))
+		return nil
+	}
+
+	if err := os.WriteFile(outPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outPath, err)
+	}
+
+	fmt.Printf("Exported %d configuration(s) to %s\n", len(export.Configurations), outPath)
+	return nil
+}
+
+func marshalConfigFile(config *ConfigFile, path string) ([]byte, error) {
+	if isYAMLPath(path) {
+		data, err := yaml.Marshal(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal YAML: %w", err)
+		}
+		return data, nil
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// importConfigurations reads a ConfigFile in JSON or YAML from path,
+// expanding ${VAR} placeholders in API keys from the environment, storing
+// each key through the secret backend, and merging or overwriting the local
+// config.
+func importConfigurations(path string, merge bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var imported ConfigFile
+	if isYAMLPath(path) {
+		if err := yaml.Unmarshal(data, &imported); err != nil {
+			return fmt.Errorf("failed to parse %s as YAML: %w", path, err)
+		}
+	} else {
+		if err := json.Unmarshal(data, &imported); err != nil {
+			return fmt.Errorf("failed to parse %s as JSON: %w", path, err)
+		}
+	}
+
+	for i := range imported.Configurations {
+		conf := &imported.Configurations[i]
+		apiKey := os.ExpandEnv(conf.APIKey)
+
+		ref, err := storeAPIKey(conf.Name, apiKey)
+		if err != nil {
+			return fmt.Errorf("failed to store API key for '%s': %w", conf.Name, err)
+		}
+		conf.APIKey = ref
+	}
+
+	var config *ConfigFile
+	if merge {
+		config, err = loadConfig()
+		if err != nil {
+			return err
+		}
+		for _, conf := range imported.Configurations {
+			// Active is a single-winner invariant enforced by
+			// setActiveConfiguration, not something a plain struct copy can
+			// honor safely - preserve the existing bit unless this entry
+			// explicitly requests activation.
+			requestsActivation := conf.Active
+
+			if existing := findConfiguration(config, conf.Name); existing != nil {
+				conf.Active = existing.Active
+				*existing = conf
+			} else {
+				conf.Active = false
+				config.Configurations = append(config.Configurations, conf)
+			}
+
+			if requestsActivation {
+				setActiveConfiguration(config, conf.Name)
+			}
+		}
+	} else {
+		config = &imported
+		normalizeActiveConfiguration(config)
+	}
+
+	if err := saveConfig(config); err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %d configuration(s) from %s\n", len(imported.Configurations), path)
+	return nil
+}
+
+// normalizeActiveConfiguration ensures at most one configuration ends up
+// marked active after an --overwrite import, keeping the single-active
+// invariant setActiveConfiguration maintains elsewhere. The last entry
+// marked active in the imported file wins.
+func normalizeActiveConfiguration(config *ConfigFile) {
+	activeName := ""
+	for _, conf := range config.Configurations {
+		if conf.Active {
+			activeName = conf.Name
+		}
+	}
+	if activeName != "" {
+		setActiveConfiguration(config, activeName)
+	}
+}
+
+// importFromEnvNow re-runs the platform auto-import - normally only
+// performed automatically when ccc-config.json is missing - on demand,
+// merging the result into the existing config.
+func importFromEnvNow() error {
+	var imported *Configuration
+	switch runtime.GOOS {
+	case "windows":
+		imported = importFromWindows()
+	case "linux", "darwin":
+		imported = importFromUnixSettings()
+	}
+
+	if imported == nil {
+		return fmt.Errorf("no ANTHROPIC_BASE_URL/ANTHROPIC_AUTH_TOKEN found in the environment or settings.json")
+	}
+
+	return mergeImportedConfiguration(imported)
+}
+
+// importFromSettingsFile bootstraps a configuration from an arbitrary
+// Claude settings.json-shaped file, e.g. `ccc import --from-file
+// ~/.claude/settings.json`.
+func importFromSettingsFile(path string) error {
+	imported := importFromUnixSettingsFile(path)
+	if imported == nil {
+		return fmt.Errorf("no ANTHROPIC_BASE_URL/ANTHROPIC_AUTH_TOKEN found in %s", path)
+	}
+
+	return mergeImportedConfiguration(imported)
+}
+
+func mergeImportedConfiguration(imported *Configuration) error {
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	ref, err := storeAPIKey(imported.Name, imported.APIKey)
+	if err != nil {
+		return err
+	}
+	imported.APIKey = ref
+
+	if existing := findConfiguration(config, imported.Name); existing != nil {
+		*existing = *imported
+	} else {
+		config.Configurations = append(config.Configurations, *imported)
+	}
+
+	if imported.Active {
+		setActiveConfiguration(config, imported.Name)
+	}
+
+	if err := saveConfig(config); err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported configuration '%s'\n", imported.Name)
+	return nil
+}
@@ -11,34 +11,52 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"time"
 )
 
 // Configuration represents a Claude Code configuration entry
 type Configuration struct {
-	Name    string `json:"name"`
-	BaseURL string `json:"base_url"`
-	APIKey  string `json:"api_key"`
-	Active  bool   `json:"active"`
+	Name    string            `json:"name" yaml:"name"`
+	BaseURL string            `json:"base_url" yaml:"base_url"`
+	APIKey  string            `json:"api_key" yaml:"api_key"`
+	Active  bool              `json:"active" yaml:"active"`
+	Model   string            `json:"model,omitempty" yaml:"model,omitempty"`
+	Env     map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
 }
 
 // ConfigFile represents the structure of the configuration file
 type ConfigFile struct {
-	Configurations []Configuration `json:"configurations"`
+	Configurations []Configuration `json:"configurations" yaml:"configurations"`
 }
 
 var configFileName = "ccc-config.json"
 
 func main() {
 	if len(os.Args) < 2 {
-		printUsage()
-		os.Exit(1)
+		if err := runTUI(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
 	}
 
 	command := os.Args[1]
 
 	switch command {
+	case "tui":
+		if err := runTUI(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
 	case "list", "ls":
-		if err := listConfigurations(); err != nil {
+		listCmd := flag.NewFlagSet("list", flag.ExitOnError)
+		check := listCmd.Bool("check", false, "Add a Health column by probing each configuration's Base URL")
+		timeout := listCmd.Duration("timeout", 5*time.Second, "Timeout for --check connectivity probes")
+
+		listCmd.Parse(os.Args[2:])
+
+		if err := listConfigurations(*check, *timeout); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -48,6 +66,9 @@ func main() {
 		name := addCmd.String("n", "", "Configuration name (required)")
 		baseURL := addCmd.String("u", "", "Base URL for API (required)")
 		apiKey := addCmd.String("k", "", "API key (required)")
+		model := addCmd.String("m", "", "Model override (sets ANTHROPIC_MODEL on activation)")
+		var envFlagsList envFlags
+		addCmd.Var(&envFlagsList, "e", "Extra env var KEY=VALUE to set on activation (repeatable)")
 
 		addCmd.Parse(os.Args[2:])
 
@@ -57,7 +78,13 @@ func main() {
 			os.Exit(1)
 		}
 
-		if err := addConfiguration(*name, *baseURL, *apiKey); err != nil {
+		env, err := envFlagsList.parse()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := addConfiguration(*name, *baseURL, *apiKey, *model, env); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -67,6 +94,9 @@ func main() {
 		name := updateCmd.String("n", "", "Configuration name (required)")
 		baseURL := updateCmd.String("u", "", "New base URL for API")
 		apiKey := updateCmd.String("k", "", "New API key")
+		model := updateCmd.String("m", "", "New model override (sets ANTHROPIC_MODEL on activation)")
+		var envFlagsList envFlags
+		updateCmd.Var(&envFlagsList, "e", "Extra env var KEY=VALUE to set on activation (repeatable)")
 
 		updateCmd.Parse(os.Args[2:])
 
@@ -76,7 +106,25 @@ func main() {
 			os.Exit(1)
 		}
 
-		if err := updateConfiguration(*name, *baseURL, *apiKey); err != nil {
+		env, err := envFlagsList.parse()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := updateConfiguration(*name, *baseURL, *apiKey, *model, env); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "env":
+		if len(os.Args) < 3 {
+			fmt.Fprintln(os.Stderr, "Error: configuration name is required")
+			fmt.Fprintln(os.Stderr, "Usage: ccc env <name>")
+			os.Exit(1)
+		}
+
+		if err := printEnvForConfiguration(os.Args[2]); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -101,6 +149,7 @@ func main() {
 	case "activate":
 		activateCmd := flag.NewFlagSet("activate", flag.ExitOnError)
 		name := activateCmd.String("n", "", "Configuration name (required)")
+		shellMode := activateCmd.Bool("shell", false, "Print shell-eval export statements for the current shell instead of mutating settings.json/env")
 
 		activateCmd.Parse(os.Args[2:])
 
@@ -110,11 +159,132 @@ func main() {
 			os.Exit(1)
 		}
 
+		if *shellMode {
+			if err := runShellActivate(*name, ""); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
 		if err := activateConfiguration(*name); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
+	case "use":
+		if len(os.Args) < 3 {
+			fmt.Fprintln(os.Stderr, "Error: configuration name is required")
+			fmt.Fprintln(os.Stderr, "Usage: ccc use <name> [--shell bash|zsh|fish|pwsh|cmd]")
+			os.Exit(1)
+		}
+		name := os.Args[2]
+
+		useCmd := flag.NewFlagSet("use", flag.ExitOnError)
+		shellFlag := useCmd.String("shell", "", "Shell format to emit: bash, zsh, fish, pwsh, cmd (default: auto-detect)")
+		useCmd.Parse(os.Args[3:])
+
+		if err := runShellActivate(name, *shellFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "completion":
+		if len(os.Args) < 3 {
+			fmt.Fprintln(os.Stderr, "Error: shell is required")
+			fmt.Fprintln(os.Stderr, "Usage: ccc completion bash|zsh|fish|pwsh")
+			os.Exit(1)
+		}
+
+		script, err := completionScript(os.Args[2])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(script)
+
+	case "__complete_names":
+		printCompletionNames()
+
+	case "export":
+		exportCmd := flag.NewFlagSet("export", flag.ExitOnError)
+		name := exportCmd.String("n", "", "Configuration name to export")
+		all := exportCmd.Bool("all", false, "Export all configurations")
+		out := exportCmd.String("o", "", "Output file (.json or .yaml/.yml); defaults to stdout as JSON")
+		redact := exportCmd.Bool("redact", false, "Replace API keys with ${ENV_VAR} placeholders")
+
+		exportCmd.Parse(os.Args[2:])
+
+		if err := exportConfigurations(*name, *all, *out, *redact); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "import":
+		importCmd := flag.NewFlagSet("import", flag.ExitOnError)
+		merge := importCmd.Bool("merge", true, "Merge into the existing config (default)")
+		overwrite := importCmd.Bool("overwrite", false, "Replace the existing config entirely")
+		fromEnv := importCmd.Bool("from-env", false, "Re-run auto-import from the environment/settings.json")
+		fromFile := importCmd.String("from-file", "", "Bootstrap from an existing Claude settings.json-shaped file")
+
+		args := os.Args[2:]
+		var file string
+		if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+			file = args[0]
+			args = args[1:]
+		}
+		importCmd.Parse(args)
+
+		var err error
+		switch {
+		case *fromEnv:
+			err = importFromEnvNow()
+		case *fromFile != "":
+			err = importFromSettingsFile(*fromFile)
+		case file != "":
+			err = importConfigurations(file, !*overwrite && *merge)
+		default:
+			fmt.Fprintln(os.Stderr, "Error: provide a file, --from-env, or --from-file")
+			importCmd.PrintDefaults()
+			os.Exit(1)
+		}
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "test":
+		testCmd := flag.NewFlagSet("test", flag.ExitOnError)
+		name := testCmd.String("n", "", "Configuration name to test")
+		all := testCmd.Bool("all", false, "Test every configuration")
+		timeout := testCmd.Duration("timeout", 5*time.Second, "Timeout per connectivity probe")
+		jsonOutput := testCmd.Bool("json", false, "Output results as JSON")
+
+		testCmd.Parse(os.Args[2:])
+
+		if err := runTestCommand(*name, *all, *timeout, *jsonOutput); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "migrate":
+		migrateCmd := flag.NewFlagSet("migrate", flag.ExitOnError)
+		encrypt := migrateCmd.Bool("encrypt", false, "Move plaintext API keys into the OS keyring (or encrypted file fallback)")
+
+		migrateCmd.Parse(os.Args[2:])
+
+		if !*encrypt {
+			fmt.Fprintln(os.Stderr, "Error: migrate requires --encrypt")
+			migrateCmd.PrintDefaults()
+			os.Exit(1)
+		}
+
+		if err := migrateEncrypt(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", command)
 		printUsage()
@@ -130,12 +300,20 @@ func printUsage() {
 	fmt.Println("  ccc <command> [flags]")
 	fmt.Println()
 	fmt.Println("Available commands:")
+	fmt.Println("  tui       Launch the interactive TUI (default when run with no command)")
 	fmt.Println("  list      List all configurations")
 	fmt.Println("  ls        Alias for list")
 	fmt.Println("  add       Add a new configuration")
 	fmt.Println("  update    Update an existing configuration")
 	fmt.Println("  delete    Delete a configuration")
 	fmt.Println("  activate  Activate a configuration and apply settings")
+	fmt.Println("  use       Print shell-eval statements to activate a configuration for this shell only")
+	fmt.Println("  env       Print a configuration's merged env vars in shell-eval form")
+	fmt.Println("  export    Export configurations as JSON/YAML, optionally redacted")
+	fmt.Println("  import    Import configurations from a file, the environment, or a settings.json")
+	fmt.Println("  test      Test connectivity/auth against a configuration's Base URL")
+	fmt.Println("  migrate   Migrate stored configuration data (e.g. --encrypt)")
+	fmt.Println("  completion Print a shell completion script (bash, zsh, fish, pwsh)")
 	fmt.Println()
 	fmt.Println("Use 'ccc <command> -h' for more information about a command.")
 }
@@ -173,6 +351,13 @@ func loadConfig() (*ConfigFile, error) {
 		}
 
 		if importedConfig != nil {
+			// Store the imported key through the secret backend rather than
+			// writing it to disk in plaintext. If storing fails, the
+			// plaintext key is kept and saved as-is.
+			if ref, err := storeAPIKey(importedConfig.Name, importedConfig.APIKey); err == nil {
+				importedConfig.APIKey = ref
+			}
+
 			// Create config with imported configuration
 			config := &ConfigFile{
 				Configurations: []Configuration{*importedConfig},
@@ -217,7 +402,7 @@ func saveConfig(config *ConfigFile) error {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
@@ -242,7 +427,7 @@ func setActiveConfiguration(config *ConfigFile, activeName string) {
 }
 
 // listConfigurations lists all configurations
-func listConfigurations() error {
+func listConfigurations(check bool, timeout time.Duration) error {
 	config, err := loadConfig()
 	if err != nil {
 		return err
@@ -263,6 +448,14 @@ func listConfigurations() error {
 	statusWidth := 6 // "Status" length
 	urlWidth := 8    // "Base URL" length
 	apiKeyWidth := 7 // "API Key" length
+	healthWidth := 6 // "Health" length
+
+	healthByName := make(map[string]string, len(config.Configurations))
+	if check {
+		for _, conf := range config.Configurations {
+			healthByName[conf.Name] = healthColumn(checkHealth(conf, timeout))
+		}
+	}
 
 	for _, conf := range config.Configurations {
 		if len(conf.Name) > nameWidth {
@@ -281,10 +474,14 @@ func listConfigurations() error {
 			urlWidth = len(conf.BaseURL)
 		}
 
-		maskedKey := maskAPIKey(conf.APIKey)
+		maskedKey := maskedAPIKeyForDisplay(conf)
 		if len(maskedKey) > apiKeyWidth {
 			apiKeyWidth = len(maskedKey)
 		}
+
+		if len(healthByName[conf.Name]) > healthWidth {
+			healthWidth = len(healthByName[conf.Name])
+		}
 	}
 
 	// Add padding
@@ -292,18 +489,24 @@ func listConfigurations() error {
 	statusWidth += 2
 	urlWidth += 2
 	apiKeyWidth += 2
+	healthWidth += 2
 
 	// Print table header
-	fmt.Printf("%-*s%-*s%-*s%-*s\n",
-		nameWidth, "Name",
-		statusWidth, "Status",
-		urlWidth, "Base URL",
-		apiKeyWidth, "API Key")
-	fmt.Printf("%-*s%-*s%-*s%-*s\n",
+	fmt.Printf("%-*s%-*s%-*s%-*s", nameWidth, "Name", statusWidth, "Status", urlWidth, "Base URL", apiKeyWidth, "API Key")
+	if check {
+		fmt.Printf("%-*s", healthWidth, "Health")
+	}
+	fmt.Println()
+
+	fmt.Printf("%-*s%-*s%-*s%-*s",
 		nameWidth, strings.Repeat("-", nameWidth-2),
 		statusWidth, strings.Repeat("-", statusWidth-2),
 		urlWidth, strings.Repeat("-", urlWidth-2),
 		apiKeyWidth, strings.Repeat("-", apiKeyWidth-2))
+	if check {
+		fmt.Printf("%-*s", healthWidth, strings.Repeat("-", healthWidth-2))
+	}
+	fmt.Println()
 
 	// Print table rows
 	for _, conf := range config.Configurations {
@@ -312,18 +515,22 @@ func listConfigurations() error {
 			status = "Active"
 		}
 
-		fmt.Printf("%-*s%-*s%-*s%-*s\n",
+		fmt.Printf("%-*s%-*s%-*s%-*s",
 			nameWidth, conf.Name,
 			statusWidth, status,
 			urlWidth, conf.BaseURL,
-			apiKeyWidth, maskAPIKey(conf.APIKey))
+			apiKeyWidth, maskedAPIKeyForDisplay(conf))
+		if check {
+			fmt.Printf("%-*s", healthWidth, healthByName[conf.Name])
+		}
+		fmt.Println()
 	}
 
 	return nil
 }
 
 // addConfiguration adds a new configuration
-func addConfiguration(name, baseURL, apiKey string) error {
+func addConfiguration(name, baseURL, apiKey, model string, env map[string]string) error {
 	config, err := loadConfig()
 	if err != nil {
 		return err
@@ -334,12 +541,19 @@ func addConfiguration(name, baseURL, apiKey string) error {
 		return fmt.Errorf("configuration with name '%s' already exists", name)
 	}
 
+	apiKeyRef, err := storeAPIKey(name, apiKey)
+	if err != nil {
+		return err
+	}
+
 	// Create new configuration
 	newConf := Configuration{
 		Name:    name,
 		BaseURL: baseURL,
-		APIKey:  apiKey,
+		APIKey:  apiKeyRef,
 		Active:  false,
+		Model:   model,
+		Env:     env,
 	}
 
 	// If this is the first configuration, make it active
@@ -363,7 +577,7 @@ func addConfiguration(name, baseURL, apiKey string) error {
 }
 
 // updateConfiguration updates an existing configuration
-func updateConfiguration(name, baseURL, apiKey string) error {
+func updateConfiguration(name, baseURL, apiKey, model string, env map[string]string) error {
 	config, err := loadConfig()
 	if err != nil {
 		return err
@@ -379,7 +593,22 @@ func updateConfiguration(name, baseURL, apiKey string) error {
 		conf.BaseURL = baseURL
 	}
 	if apiKey != "" {
-		conf.APIKey = apiKey
+		apiKeyRef, err := storeAPIKey(name, apiKey)
+		if err != nil {
+			return err
+		}
+		conf.APIKey = apiKeyRef
+	}
+	if model != "" {
+		conf.Model = model
+	}
+	if len(env) > 0 {
+		if conf.Env == nil {
+			conf.Env = make(map[string]string, len(env))
+		}
+		for k, v := range env {
+			conf.Env[k] = v
+		}
 	}
 
 	if err := saveConfig(config); err != nil {
@@ -407,6 +636,10 @@ func deleteConfiguration(name string) error {
 		return fmt.Errorf("cannot delete active configuration '%s'. Please activate another configuration first", name)
 	}
 
+	if err := deleteAPIKey(conf.APIKey); err != nil {
+		return err
+	}
+
 	// Remove the configuration
 	var newConfigurations []Configuration
 	for _, c := range config.Configurations {
@@ -479,18 +712,23 @@ func NewClaudeSettings() ClaudeSettings {
 
 // setWindowsEnvironmentVariables sets environment variables on Windows
 func setWindowsEnvironmentVariables(activeConfig *Configuration) error {
+	apiKey, err := resolveAPIKey(activeConfig.APIKey)
+	if err != nil {
+		return fmt.Errorf("failed to resolve API key for '%s': %w", activeConfig.Name, err)
+	}
+
 	// Set environment variables for the current process
 	if err := os.Setenv("ANTHROPIC_BASE_URL", activeConfig.BaseURL); err != nil {
 		return fmt.Errorf("failed to set ANTHROPIC_BASE_URL: %w", err)
 	}
 
-	if err := os.Setenv("ANTHROPIC_AUTH_TOKEN", activeConfig.APIKey); err != nil {
+	if err := os.Setenv("ANTHROPIC_AUTH_TOKEN", apiKey); err != nil {
 		return fmt.Errorf("failed to set ANTHROPIC_AUTH_TOKEN: %w", err)
 	}
 
 	fmt.Printf("Environment variables set for active configuration '%s':\n", activeConfig.Name)
 	fmt.Printf("ANTHROPIC_BASE_URL=%s\n", activeConfig.BaseURL)
-	fmt.Printf("ANTHROPIC_AUTH_TOKEN=%s\n", maskAPIKey(activeConfig.APIKey))
+	fmt.Printf("ANTHROPIC_AUTH_TOKEN=%s\n", maskAPIKey(apiKey))
 
 	// Also set them permanently using setx
 	fmt.Println("\nSetting permanent environment variables...")
@@ -503,7 +741,7 @@ func setWindowsEnvironmentVariables(activeConfig *Configuration) error {
 		fmt.Println("Successfully set permanent ANTHROPIC_BASE_URL")
 	}
 
-	setxAuthToken := exec.Command("setx", "ANTHROPIC_AUTH_TOKEN", activeConfig.APIKey)
+	setxAuthToken := exec.Command("setx", "ANTHROPIC_AUTH_TOKEN", apiKey)
 	if output, err := setxAuthToken.CombinedOutput(); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: Failed to set permanent ANTHROPIC_AUTH_TOKEN: %v\n", err)
 		fmt.Fprintf(os.Stderr, "Output: %s\n", string(output))
@@ -511,6 +749,31 @@ func setWindowsEnvironmentVariables(activeConfig *Configuration) error {
 		fmt.Println("Successfully set permanent ANTHROPIC_AUTH_TOKEN")
 	}
 
+	// Profile-scoped model and extra env overrides
+	extras := map[string]string{}
+	if activeConfig.Model != "" {
+		extras["ANTHROPIC_MODEL"] = activeConfig.Model
+	}
+	for k, v := range activeConfig.Env {
+		extras[k] = v
+	}
+
+	for key, value := range extras {
+		if err := os.Setenv(key, value); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to set %s: %v\n", key, err)
+			continue
+		}
+		fmt.Printf("%s=%s\n", key, value)
+
+		setxExtra := exec.Command("setx", key, value)
+		if output, err := setxExtra.CombinedOutput(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to set permanent %s: %v\n", key, err)
+			fmt.Fprintf(os.Stderr, "Output: %s\n", string(output))
+		} else {
+			fmt.Printf("Successfully set permanent %s\n", key)
+		}
+	}
+
 	fmt.Println("\nNote: Permanent environment variables will be available in new command prompt windows.")
 
 	return nil
@@ -518,6 +781,11 @@ func setWindowsEnvironmentVariables(activeConfig *Configuration) error {
 
 // setUnixSettingsFile updates the Claude settings.json file on Linux/macOS
 func setUnixSettingsFile(activeConfig *Configuration) error {
+	apiKey, err := resolveAPIKey(activeConfig.APIKey)
+	if err != nil {
+		return fmt.Errorf("failed to resolve API key for '%s': %w", activeConfig.Name, err)
+	}
+
 	// Get home directory
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -553,7 +821,7 @@ func setUnixSettingsFile(activeConfig *Configuration) error {
 	}
 
 	// Update settings with active configuration
-	settings.Env["ANTHROPIC_AUTH_TOKEN"] = activeConfig.APIKey
+	settings.Env["ANTHROPIC_AUTH_TOKEN"] = apiKey
 	settings.Env["ANTHROPIC_BASE_URL"] = activeConfig.BaseURL
 
 	// Ensure default values are present
@@ -564,6 +832,15 @@ func setUnixSettingsFile(activeConfig *Configuration) error {
 		settings.Env["CLAUDE_CODE_DISABLE_NONESSENTIAL_TRAFFIC"] = 1
 	}
 
+	// Profile overrides take precedence over both the existing settings and
+	// the defaults above.
+	if activeConfig.Model != "" {
+		settings.Env["ANTHROPIC_MODEL"] = activeConfig.Model
+	}
+	for k, v := range activeConfig.Env {
+		settings.Env[k] = v
+	}
+
 	// Write updated settings back to file
 	data, err := json.MarshalIndent(settings, "", "  ")
 	if err != nil {
@@ -577,7 +854,7 @@ func setUnixSettingsFile(activeConfig *Configuration) error {
 	fmt.Printf("Claude settings updated for active configuration '%s':\n", activeConfig.Name)
 	fmt.Printf("Settings file: %s\n", settingsPath)
 	fmt.Printf("Base URL: %s\n", activeConfig.BaseURL)
-	fmt.Printf("API Key: %s\n", maskAPIKey(activeConfig.APIKey))
+	fmt.Printf("API Key: %s\n", maskAPIKey(apiKey))
 
 	return nil
 }
@@ -645,7 +922,13 @@ func importFromUnixSettings() *Configuration {
 		return nil
 	}
 
-	settingsPath := filepath.Join(homeDir, ".claude", "settings.json")
+	return importFromUnixSettingsFile(filepath.Join(homeDir, ".claude", "settings.json"))
+}
+
+// importFromUnixSettingsFile imports a configuration from an arbitrary
+// Claude settings.json-shaped file, letting `ccc import --from-file` bootstrap
+// from a settings.json that isn't at the default ~/.claude location.
+func importFromUnixSettingsFile(settingsPath string) *Configuration {
 	data, err := os.ReadFile(settingsPath)
 	if err != nil {
 		return nil
@@ -676,6 +959,17 @@ func importFromUnixSettings() *Configuration {
 	}
 }
 
+// maskedAPIKeyForDisplay resolves a configuration's stored key reference and
+// masks the real key, falling back to masking the raw reference if it can't
+// be resolved (e.g. the OS keyring is locked).
+func maskedAPIKeyForDisplay(conf Configuration) string {
+	apiKey, err := resolveAPIKey(conf.APIKey)
+	if err != nil {
+		return maskAPIKey(conf.APIKey)
+	}
+	return maskAPIKey(apiKey)
+}
+
 // maskAPIKey masks the API key for display
 func maskAPIKey(apiKey string) string {
 	if len(apiKey) <= 8 {
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+	"golang.org/x/crypto/scrypt"
+)
+
+const keyringService = "ccc"
+const keyringRefPrefix = "keyring:ccc/"
+const encFileRefPrefix = "encfile:"
+
+// storeAPIKey persists apiKey in the platform secret backend (OS keyring, or
+// an encrypted file when no keyring is available) and returns an opaque
+// reference suitable for the Configuration.APIKey field on disk.
+func storeAPIKey(name, apiKey string) (string, error) {
+	if err := keyring.Set(keyringService, name, apiKey); err == nil {
+		return keyringRefPrefix + name, nil
+	}
+
+	// No usable OS keyring (e.g. headless Linux without a Secret Service
+	// provider) - fall back to a file encrypted with AES-GCM under an
+	// scrypt-derived key.
+	if err := setEncryptedFileSecret(name, apiKey); err != nil {
+		return "", fmt.Errorf("failed to store API key for '%s': %w", name, err)
+	}
+	return encFileRefPrefix + name, nil
+}
+
+// resolveAPIKey turns a Configuration.APIKey value - which may be a
+// plaintext key left over from before secret-backend support, or an opaque
+// reference - into the real API key to use on the wire.
+func resolveAPIKey(ref string) (string, error) {
+	switch {
+	case strings.HasPrefix(ref, keyringRefPrefix):
+		name := strings.TrimPrefix(ref, keyringRefPrefix)
+		key, err := keyring.Get(keyringService, name)
+		if err != nil {
+			return "", fmt.Errorf("failed to read '%s' from OS keyring: %w", name, err)
+		}
+		return key, nil
+
+	case strings.HasPrefix(ref, encFileRefPrefix):
+		return getEncryptedFileSecret(strings.TrimPrefix(ref, encFileRefPrefix))
+
+	default:
+		// Plaintext key, stored before secret-backend support existed.
+		return ref, nil
+	}
+}
+
+// deleteAPIKey removes the secret a reference points to. References to
+// plaintext keys are a no-op since there's nothing backing them to clean up.
+func deleteAPIKey(ref string) error {
+	switch {
+	case strings.HasPrefix(ref, keyringRefPrefix):
+		name := strings.TrimPrefix(ref, keyringRefPrefix)
+		if err := keyring.Delete(keyringService, name); err != nil && err != keyring.ErrNotFound {
+			return fmt.Errorf("failed to delete '%s' from OS keyring: %w", name, err)
+		}
+		return nil
+
+	case strings.HasPrefix(ref, encFileRefPrefix):
+		return deleteEncryptedFileSecret(strings.TrimPrefix(ref, encFileRefPrefix))
+
+	default:
+		return nil
+	}
+}
+
+// encryptedSecretFile is the on-disk format of the Linux keyring fallback:
+// one scrypt salt for the whole file, and one AES-GCM sealed blob per secret.
+type encryptedSecretFile struct {
+	Salt    string            `json:"salt"`
+	Secrets map[string]string `json:"secrets"`
+}
+
+func secretsFilePath() (string, error) {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), "ccc-secrets.enc"), nil
+}
+
+// masterKeyPassphrase returns the passphrase used to derive the encrypted
+// file's key. The passphrase is read only from CCC_MASTER_KEY; there is no
+// interactive prompt, so the fallback returns an error when it is unset.
+func masterKeyPassphrase() (string, error) {
+	if v := os.Getenv("CCC_MASTER_KEY"); v != "" {
+		return v, nil
+	}
+	return "", fmt.Errorf("no OS keyring is available and CCC_MASTER_KEY is not set; " +
+		"set CCC_MASTER_KEY to a passphrase to use the encrypted file fallback")
+}
+
+func loadSecretsFile() (*encryptedSecretFile, error) {
+	path, err := secretsFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, fmt.Errorf("failed to generate salt: %w", err)
+		}
+		return &encryptedSecretFile{
+			Salt:    base64.StdEncoding.EncodeToString(salt),
+			Secrets: map[string]string{},
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var f encryptedSecretFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if f.Secrets == nil {
+		f.Secrets = map[string]string{}
+	}
+	return &f, nil
+}
+
+func saveSecretsFile(f *encryptedSecretFile) error {
+	path, err := secretsFilePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal secrets file: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func deriveFileKey(passphrase string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+}
+
+func setEncryptedFileSecret(name, value string) error {
+	passphrase, err := masterKeyPassphrase()
+	if err != nil {
+		return err
+	}
+
+	f, err := loadSecretsFile()
+	if err != nil {
+		return err
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(f.Salt)
+	if err != nil {
+		return fmt.Errorf("corrupt secrets file salt: %w", err)
+	}
+	key, err := deriveFileKey(passphrase, salt)
+	if err != nil {
+		return fmt.Errorf("failed to derive encryption key: %w", err)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(value), nil)
+	f.Secrets[name] = base64.StdEncoding.EncodeToString(sealed)
+
+	return saveSecretsFile(f)
+}
+
+func getEncryptedFileSecret(name string) (string, error) {
+	passphrase, err := masterKeyPassphrase()
+	if err != nil {
+		return "", err
+	}
+
+	f, err := loadSecretsFile()
+	if err != nil {
+		return "", err
+	}
+
+	encoded, ok := f.Secrets[name]
+	if !ok {
+		return "", fmt.Errorf("no encrypted secret found for '%s'", name)
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(f.Salt)
+	if err != nil {
+		return "", fmt.Errorf("corrupt secrets file salt: %w", err)
+	}
+	key, err := deriveFileKey(passphrase, salt)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive encryption key: %w", err)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("corrupt secret for '%s': %w", name, err)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", fmt.Errorf("corrupt secret for '%s'", name)
+	}
+	nonce, ciphertext := sealed[:nonceSize], sealed[nonceSize:]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt secret for '%s' (wrong CCC_MASTER_KEY?): %w", name, err)
+	}
+
+	return string(plaintext), nil
+}
+
+func deleteEncryptedFileSecret(name string) error {
+	f, err := loadSecretsFile()
+	if err != nil {
+		return err
+	}
+	delete(f.Secrets, name)
+	return saveSecretsFile(f)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init GCM: %w", err)
+	}
+	return gcm, nil
+}
+
+// migrateEncrypt moves any plaintext API keys in the config file into the
+// secret backend, replacing them with opaque references.
+func migrateEncrypt() error {
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	migrated := 0
+	for i := range config.Configurations {
+		conf := &config.Configurations[i]
+		if strings.HasPrefix(conf.APIKey, keyringRefPrefix) || strings.HasPrefix(conf.APIKey, encFileRefPrefix) {
+			continue
+		}
+
+		ref, err := storeAPIKey(conf.Name, conf.APIKey)
+		if err != nil {
+			return fmt.Errorf("failed to migrate '%s': %w", conf.Name, err)
+		}
+		conf.APIKey = ref
+		migrated++
+	}
+
+	if err := saveConfig(config); err != nil {
+		return err
+	}
+
+	fmt.Printf("Migrated %d configuration(s) to encrypted storage.\n", migrated)
+	return nil
+}
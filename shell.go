@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// detectShell guesses the invoking shell from the environment so `ccc use`
+// can format output correctly without an explicit --shell flag.
+func detectShell() string {
+	if runtime.GOOS == "windows" {
+		if os.Getenv("PSModulePath") != "" {
+			return "pwsh"
+		}
+		return "cmd"
+	}
+
+	switch filepath.Base(os.Getenv("SHELL")) {
+	case "zsh":
+		return "zsh"
+	case "fish":
+		return "fish"
+	default:
+		return "bash"
+	}
+}
+
+// formatShellAssignment renders a KEY=VALUE env assignment in the syntax of
+// the given shell.
+func formatShellAssignment(kind, key, value string) string {
+	switch kind {
+	case "fish":
+		return fmt.Sprintf("set -x %s %s", key, shellQuote(value))
+	case "pwsh":
+		return fmt.Sprintf("$env:%s = \"%s\"", key, pwshQuote(value))
+	case "cmd":
+		return fmt.Sprintf("set %s=%s", key, cmdEscape(value))
+	default: // bash, zsh
+		return fmt.Sprintf("export %s=%s", key, shellQuote(value))
+	}
+}
+
+// pwshQuote escapes a value for safe use inside a PowerShell double-quoted
+// string: backtick is the escape character, and `$` and `"` must both be
+// escaped with it to prevent variable/subexpression expansion and premature
+// string termination.
+func pwshQuote(value string) string {
+	return strings.NewReplacer(
+		"`", "``",
+		"$", "`$",
+		`"`, "`\"",
+	).Replace(value)
+}
+
+// cmdEscape caret-escapes cmd.exe metacharacters so a value emitted into a
+// bare `set KEY=VALUE` line can't be parsed as additional statements.
+func cmdEscape(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		switch r {
+		case '^', '&', '|', '<', '>', '(', ')', '"':
+			b.WriteByte('^')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// runShellActivate prints shell-eval statements for a configuration's merged
+// env instead of mutating settings.json or the registry, so `eval "$(ccc use
+// work)"` only affects the current shell.
+func runShellActivate(name, explicitShell string) error {
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	conf := findConfiguration(config, name)
+	if conf == nil {
+		return fmt.Errorf("configuration with name '%s' not found", name)
+	}
+
+	kind := explicitShell
+	if kind == "" {
+		kind = detectShell()
+	}
+
+	env, err := mergedEnvForConfig(conf)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fmt.Println(formatShellAssignment(kind, k, env[k]))
+	}
+
+	return nil
+}
+
+// completionScript returns the dynamic-name completion script for the given
+// shell. Each script shells out to the hidden `ccc __complete_names` command
+// so completions stay in sync with loadConfig.
+func completionScript(shell string) (string, error) {
+	switch shell {
+	case "bash":
+		return `_ccc_complete_names() {
+    COMPREPLY=( $(compgen -W "$(ccc __complete_names 2>/dev/null)" -- "${COMP_WORDS[COMP_CWORD]}") )
+}
+complete -F _ccc_complete_names ccc
+`, nil
+
+	case "zsh":
+		return `#compdef ccc
+
+_ccc() {
+  local -a names
+  names=(${(f)"$(ccc __complete_names 2>/dev/null)"})
+  _describe 'configuration' names
+}
+
+compdef _ccc ccc
+`, nil
+
+	case "fish":
+		return `function __ccc_complete_names
+    ccc __complete_names 2>/dev/null
+end
+
+complete -c ccc -f -a '(__ccc_complete_names)'
+`, nil
+
+	case "pwsh":
+		return `Register-ArgumentCompleter -Native -CommandName ccc -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+    ccc __complete_names 2>$null | Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+    }
+}
+`, nil
+
+	default:
+		return "", fmt.Errorf("unsupported shell '%s' (expected bash, zsh, fish, or pwsh)", shell)
+	}
+}
+
+// printCompletionNames prints one configuration name per line for shell
+// completion scripts to consume. Errors are swallowed since a completion
+// script has no good way to surface them.
+func printCompletionNames() {
+	config, err := loadConfig()
+	if err != nil {
+		return
+	}
+	for _, conf := range config.Configurations {
+		fmt.Println(conf.Name)
+	}
+}
@@ -0,0 +1,383 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tuiMode identifies which view the TUI is currently showing
+type tuiMode int
+
+const (
+	tuiModeList tuiMode = iota
+	tuiModeAdd
+	tuiModeEdit
+)
+
+// tuiAddField identifies which field is being edited in the add/edit form
+type tuiAddField int
+
+const (
+	tuiFieldName tuiAddField = iota
+	tuiFieldBaseURL
+	tuiFieldAPIKey
+)
+
+// tuiModel is the Bubble Tea model backing `ccc` / `ccc tui`
+type tuiModel struct {
+	config   *ConfigFile
+	filtered []Configuration
+	cursor   int
+	mode     tuiMode
+
+	filterInput textinput.Model
+	formInputs  []textinput.Model
+	formField   tuiAddField
+	editing     string // name of the configuration being edited, empty when adding
+
+	status string
+	err    error
+}
+
+// runTUI starts the interactive TUI
+func runTUI() error {
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	m := newTUIModel(config)
+
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	_, err = p.Run()
+	return err
+}
+
+func newTUIModel(config *ConfigFile) tuiModel {
+	filterInput := textinput.New()
+	filterInput.Placeholder = "type to filter by name or URL"
+	filterInput.Focus()
+
+	m := tuiModel{
+		config:      config,
+		filterInput: filterInput,
+		mode:        tuiModeList,
+	}
+	m.applyFilter()
+	return m
+}
+
+func (m *tuiModel) applyFilter() {
+	query := strings.TrimSpace(m.filterInput.Value())
+
+	m.filtered = m.filtered[:0]
+	for _, conf := range m.config.Configurations {
+		if query == "" || fuzzyMatch(query, conf.Name) || fuzzyMatch(query, conf.BaseURL) {
+			m.filtered = append(m.filtered, conf)
+		}
+	}
+
+	if m.cursor >= len(m.filtered) {
+		m.cursor = len(m.filtered) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+// fuzzyMatch reports whether every rune of query appears in order within target (case-insensitive)
+func fuzzyMatch(query, target string) bool {
+	queryRunes := []rune(strings.ToLower(query))
+	target = strings.ToLower(target)
+
+	qi := 0
+	for _, r := range target {
+		if qi == len(queryRunes) {
+			break
+		}
+		if queryRunes[qi] == r {
+			qi++
+		}
+	}
+	return qi == len(queryRunes)
+}
+
+func (m tuiModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.mode != tuiModeList {
+			return m.updateForm(msg)
+		}
+		return m.updateList(msg)
+
+	case tuiTestResultMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("%s: connection failed (%v)", msg.name, msg.err)
+		} else {
+			m.status = fmt.Sprintf("%s: reachable (%s)", msg.name, msg.latency)
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m tuiModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "esc":
+		return m, tea.Quit
+
+	case "q":
+		if m.filterInput.Value() == "" {
+			return m, tea.Quit
+		}
+
+	case "up", "ctrl+p":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+
+	case "down", "ctrl+n":
+		if m.cursor < len(m.filtered)-1 {
+			m.cursor++
+		}
+		return m, nil
+
+	case "enter":
+		if conf := m.selected(); conf != nil {
+			if err := activateConfiguration(conf.Name); err != nil {
+				m.status = fmt.Sprintf("activation failed: %v", err)
+			} else {
+				m.status = fmt.Sprintf("activated '%s'", conf.Name)
+			}
+			m.refresh()
+		}
+		return m, nil
+
+	case "a":
+		m.mode = tuiModeAdd
+		m.editing = ""
+		m.formField = tuiFieldName
+		m.formInputs = newTUIForm("", "", "")
+		return m, nil
+
+	case "e":
+		if conf := m.selected(); conf != nil {
+			apiKey, err := resolveAPIKey(conf.APIKey)
+			if err != nil {
+				m.status = fmt.Sprintf("edit failed: %v", err)
+				return m, nil
+			}
+			m.mode = tuiModeEdit
+			m.editing = conf.Name
+			m.formField = tuiFieldBaseURL
+			m.formInputs = newTUIForm(conf.Name, conf.BaseURL, apiKey)
+			m.formInputs[tuiFieldName].Blur()
+			m.formInputs[tuiFieldBaseURL].Focus()
+		}
+		return m, nil
+
+	case "d":
+		if conf := m.selected(); conf != nil {
+			if err := deleteConfiguration(conf.Name); err != nil {
+				m.status = fmt.Sprintf("delete failed: %v", err)
+			} else {
+				m.status = fmt.Sprintf("deleted '%s'", conf.Name)
+			}
+			m.refresh()
+		}
+		return m, nil
+
+	case "t":
+		if conf := m.selected(); conf != nil {
+			name := conf.Name
+			baseURL := conf.BaseURL
+			apiKey, err := resolveAPIKey(conf.APIKey)
+			if err != nil {
+				m.status = fmt.Sprintf("%s: %v", name, err)
+				return m, nil
+			}
+			m.status = fmt.Sprintf("%s: testing...", name)
+			return m, func() tea.Msg {
+				return testConnectivity(name, baseURL, apiKey)
+			}
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(msg)
+	m.applyFilter()
+	return m, cmd
+}
+
+func (m *tuiModel) refresh() {
+	config, err := loadConfig()
+	if err != nil {
+		m.err = err
+		return
+	}
+	m.config = config
+	m.applyFilter()
+}
+
+func (m tuiModel) selected() *Configuration {
+	if m.cursor < 0 || m.cursor >= len(m.filtered) {
+		return nil
+	}
+	return &m.filtered[m.cursor]
+}
+
+func newTUIForm(name, baseURL, apiKey string) []textinput.Model {
+	values := []string{name, baseURL, apiKey}
+	placeholders := []string{"name", "base url", "api key"}
+
+	inputs := make([]textinput.Model, len(values))
+	for i, v := range values {
+		ti := textinput.New()
+		ti.Placeholder = placeholders[i]
+		ti.SetValue(v)
+		if i == 0 {
+			ti.Focus()
+		}
+		inputs[i] = ti
+	}
+	return inputs
+}
+
+func (m tuiModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode = tuiModeList
+		return m, nil
+
+	case "tab", "down":
+		m.formInputs[m.formField].Blur()
+		m.formField = (m.formField + 1) % tuiAddField(len(m.formInputs))
+		m.formInputs[m.formField].Focus()
+		return m, nil
+
+	case "shift+tab", "up":
+		m.formInputs[m.formField].Blur()
+		m.formField = (m.formField - 1 + tuiAddField(len(m.formInputs))) % tuiAddField(len(m.formInputs))
+		m.formInputs[m.formField].Focus()
+		return m, nil
+
+	case "enter":
+		name := m.formInputs[tuiFieldName].Value()
+		baseURL := m.formInputs[tuiFieldBaseURL].Value()
+		apiKey := m.formInputs[tuiFieldAPIKey].Value()
+
+		if m.editing == "" && (name == "" || baseURL == "" || apiKey == "") {
+			m.status = "name, base URL, and API key are required"
+			return m, nil
+		}
+
+		var err error
+		if m.editing == "" {
+			err = addConfiguration(name, baseURL, apiKey, "", nil)
+		} else {
+			err = updateConfiguration(m.editing, baseURL, apiKey, "", nil)
+		}
+
+		if err != nil {
+			m.status = fmt.Sprintf("save failed: %v", err)
+		} else {
+			m.status = fmt.Sprintf("saved '%s'", name)
+			m.mode = tuiModeList
+		}
+		m.refresh()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.formInputs[m.formField], cmd = m.formInputs[m.formField].Update(msg)
+	return m, cmd
+}
+
+func (m tuiModel) View() string {
+	var b strings.Builder
+
+	switch m.mode {
+	case tuiModeAdd, tuiModeEdit:
+		title := "Add configuration"
+		if m.mode == tuiModeEdit {
+			title = fmt.Sprintf("Edit configuration '%s'", m.editing)
+		}
+		fmt.Fprintf(&b, "%s\n\n", title)
+		for i, ti := range m.formInputs {
+			if tuiAddField(i) == tuiFieldName && m.mode == tuiModeEdit {
+				continue
+			}
+			fmt.Fprintf(&b, "%s\n", ti.View())
+		}
+		b.WriteString("\n[enter] save  [esc] cancel  [tab] next field\n")
+
+	default:
+		b.WriteString("ccc - Claude Code Configuration Manager\n")
+		fmt.Fprintf(&b, "%s\n\n", m.filterInput.View())
+
+		if len(m.filtered) == 0 {
+			b.WriteString("No matching configurations.\n")
+		}
+
+		for i, conf := range m.filtered {
+			cursor := "  "
+			if i == m.cursor {
+				cursor = "> "
+			}
+			status := " "
+			if conf.Active {
+				status = "*"
+			}
+			fmt.Fprintf(&b, "%s%s %-20s %s\n", cursor, status, conf.Name, conf.BaseURL)
+		}
+
+		b.WriteString("\n[enter] activate  [a] add  [e] edit  [d] delete  [t] test  [q] quit\n")
+	}
+
+	if m.status != "" {
+		fmt.Fprintf(&b, "\n%s\n", m.status)
+	}
+	if m.err != nil {
+		fmt.Fprintf(&b, "\nerror: %v\n", m.err)
+	}
+
+	return b.String()
+}
+
+// tuiTestResultMsg carries the result of a background connectivity check back into Update
+type tuiTestResultMsg struct {
+	name    string
+	latency time.Duration
+	err     error
+}
+
+func testConnectivity(name, baseURL, apiKey string) tuiTestResultMsg {
+	client := http.Client{Timeout: 5 * time.Second}
+
+	start := time.Now()
+	req, err := http.NewRequest(http.MethodGet, strings.TrimRight(baseURL, "/")+"/v1/models", nil)
+	if err != nil {
+		return tuiTestResultMsg{name: name, err: err}
+	}
+	req.Header.Set("x-api-key", apiKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return tuiTestResultMsg{name: name, err: err}
+	}
+	defer resp.Body.Close()
+
+	return tuiTestResultMsg{name: name, latency: time.Since(start)}
+}